@@ -0,0 +1,79 @@
+package routerrpc
+
+import (
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// RouterBackend contains the backend implementation of the router rpc
+// sub-server calls.
+type RouterBackend struct {
+	// SelfNode is the vertex of the node sending the payment.
+	SelfNode route.Vertex
+}
+
+// extractIntentFromSendRequest attempts to extract the essential arguments
+// needed to route a payment from a SendPaymentRequest, converting it into a
+// routing.LightningPayment for the payment lifecycle to consume.
+func (r *RouterBackend) extractIntentFromSendRequest(
+	rpcPayReq *SendPaymentRequest) (*routing.LightningPayment, error) {
+
+	payment := &routing.LightningPayment{
+		FinalCLTVDelta: uint16(rpcPayReq.FinalCltvDelta),
+		MaxParts:       rpcPayReq.MaxParts,
+	}
+
+	if len(rpcPayReq.PaymentHash) > 0 {
+		hash, err := lntypes.MakeHash(rpcPayReq.PaymentHash)
+		if err != nil {
+			return nil, err
+		}
+		payment.PaymentHash = hash
+	}
+
+	if len(rpcPayReq.Dest) > 0 {
+		copy(payment.Target[:], rpcPayReq.Dest)
+	}
+
+	switch {
+	case rpcPayReq.AmtMsat != 0:
+		payment.Amount = lnwire.MilliSatoshi(rpcPayReq.AmtMsat)
+	case rpcPayReq.Amt != 0:
+		payment.Amount = lnwire.MilliSatoshi(rpcPayReq.Amt * 1000)
+	}
+
+	switch {
+	case rpcPayReq.FeeLimitMsat != 0:
+		payment.FeeLimit = lnwire.MilliSatoshi(rpcPayReq.FeeLimitMsat)
+	case rpcPayReq.FeeLimitSat != 0:
+		payment.FeeLimit = lnwire.MilliSatoshi(
+			rpcPayReq.FeeLimitSat * 1000,
+		)
+	}
+
+	applyShardSizeLimits(rpcPayReq, payment)
+
+	return payment, nil
+}
+
+// applyShardSizeLimits copies the shard-size bounds requested on req onto the
+// LightningPayment that will drive the payment lifecycle. MaxShardAmt is
+// left unset when the caller didn't request one, and MinShardAmt always
+// ends up set, falling back to routing.MinShardSizeMsat when the caller
+// didn't request a floor of its own.
+func applyShardSizeLimits(req *SendPaymentRequest,
+	payment *routing.LightningPayment) {
+
+	if req.MaxShardSizeMsat > 0 {
+		maxShardAmt := lnwire.MilliSatoshi(req.MaxShardSizeMsat)
+		payment.MaxShardAmt = &maxShardAmt
+	}
+
+	minShardAmt := routing.MinShardSizeMsat
+	if req.MinShardSizeMsat > 0 {
+		minShardAmt = lnwire.MilliSatoshi(req.MinShardSizeMsat)
+	}
+	payment.MinShardAmt = &minShardAmt
+}
@@ -355,3 +355,478 @@ func testSendToRouteMultiPath(net *lntest.NetworkHarness, t *harnessTest) {
 	// ...and in Bob's list of paid invoices.
 	assertSettledInvoice(net.Bob, rHash, 3)
 }
+
+// setupAdversarialLiquidityTopology builds the four-node Alice/Carol/Dave/Bob
+// topology shared by the automatic MPP splitting tests below:
+//
+//	Alice -- Carol ---- Bob
+//	     \              /
+//	      \__ Dave ____/
+//
+// Both Alice->Carol->Bob and Alice->Dave->Bob channels are opened with size
+// chanAmt, and carolPushAmt is pushed to Carol at open time so that Alice's
+// outbound liquidity on that leg can be made deliberately short of chanAmt.
+// It returns the new nodes together with a cleanup function that closes the
+// channels and shuts the nodes down; callers should defer the returned
+// cleanup.
+func setupAdversarialLiquidityTopology(net *lntest.NetworkHarness,
+	t *harnessTest, chanAmt, carolPushAmt btcutil.Amount) (carol,
+	dave *lntest.HarnessNode, cleanup func()) {
+
+	ctxb := context.Background()
+
+	alice := net.Alice
+	bob := net.Bob
+
+	var err error
+	carol, err = net.NewNode("carol", nil)
+	if err != nil {
+		t.Fatalf("unable to create carol: %v", err)
+	}
+
+	dave, err = net.NewNode("dave", nil)
+	if err != nil {
+		t.Fatalf("unable to create dave: %v", err)
+	}
+
+	nodes := []*lntest.HarnessNode{alice, bob, carol, dave}
+
+	// Connect nodes to ensure propagation of channels.
+	for i := 0; i < len(nodes); i++ {
+		for j := i + 1; j < len(nodes); j++ {
+			ctxt, _ := context.WithTimeout(ctxb, defaultTimeout)
+			if err := net.EnsureConnected(ctxt, nodes[i], nodes[j]); err != nil {
+				t.Fatalf("unable to connect nodes: %v", err)
+			}
+		}
+	}
+
+	var networkChans []*lnrpc.ChannelPoint
+	var closeChannelFuncs []func()
+
+	// openChannel is a helper to open a channel from->to, optionally
+	// pushing funds to the remote side to create an imbalanced channel.
+	openChannel := func(from, to *lntest.HarnessNode, chanSize,
+		pushAmt btcutil.Amount) {
+
+		ctxt, _ := context.WithTimeout(ctxb, defaultTimeout)
+		err := net.SendCoins(ctxt, btcutil.SatoshiPerBitcoin, from)
+		if err != nil {
+			t.Fatalf("unable to send coins : %v", err)
+		}
+
+		ctxt, _ = context.WithTimeout(ctxb, channelOpenTimeout)
+		chanPoint := openChannelAndAssert(
+			ctxt, t, net, from, to,
+			lntest.OpenChannelParams{
+				Amt:     chanSize,
+				PushAmt: pushAmt,
+			},
+		)
+
+		closeChannelFuncs = append(closeChannelFuncs, func() {
+			ctxt, _ := context.WithTimeout(ctxb, channelCloseTimeout)
+			closeChannelAndAssert(
+				ctxt, t, net, from, chanPoint, false,
+			)
+		})
+
+		networkChans = append(networkChans, chanPoint)
+	}
+
+	openChannel(alice, carol, chanAmt, carolPushAmt)
+	openChannel(alice, dave, chanAmt, 0)
+	openChannel(carol, bob, chanAmt, 0)
+	openChannel(dave, bob, chanAmt, 0)
+
+	// Wait for all nodes to have seen all channels.
+	for _, chanPoint := range networkChans {
+		for _, node := range nodes {
+			txid, err := lnd.GetChanPointFundingTxid(chanPoint)
+			if err != nil {
+				t.Fatalf("unable to get txid: %v", err)
+			}
+			point := wire.OutPoint{
+				Hash:  *txid,
+				Index: chanPoint.OutputIndex,
+			}
+
+			ctxt, _ := context.WithTimeout(ctxb, defaultTimeout)
+			err = node.WaitForNetworkChannelOpen(ctxt, chanPoint)
+			if err != nil {
+				t.Fatalf("(%d): timeout waiting for "+
+					"channel(%s) open: %v",
+					node.NodeID, point, err)
+			}
+		}
+	}
+
+	cleanup = func() {
+		for _, f := range closeChannelFuncs {
+			f()
+		}
+		shutdownAndAssert(net, t, dave)
+		shutdownAndAssert(net, t, carol)
+	}
+
+	return carol, dave, cleanup
+}
+
+// assertInvoiceSettledWithHtlcs polls node's invoice list until the invoice
+// identified by rHash shows up, then asserts that it settled using exactly
+// num HTLCs.
+func assertInvoiceSettledWithHtlcs(t *harnessTest, node *lntest.HarnessNode,
+	rHash []byte, num int) {
+
+	ctxb := context.Background()
+
+	found := false
+	offset := uint64(0)
+	for !found {
+		ctxt, _ := context.WithTimeout(ctxb, defaultTimeout)
+		invoicesResp, err := node.ListInvoices(
+			ctxt, &lnrpc.ListInvoiceRequest{
+				IndexOffset: offset,
+			},
+		)
+		if err != nil {
+			t.Fatalf("error when obtaining invoices: %v", err)
+		}
+
+		if len(invoicesResp.Invoices) == 0 {
+			break
+		}
+
+		for _, inv := range invoicesResp.Invoices {
+			if !bytes.Equal(inv.RHash, rHash) {
+				continue
+			}
+
+			if inv.State != lnrpc.Invoice_SETTLED {
+				t.Fatalf("invoice not settled: %v", inv.State)
+			}
+
+			if len(inv.Htlcs) != num {
+				t.Fatalf("expected invoice to be settled "+
+					"with %v HTLCs, had %v", num,
+					len(inv.Htlcs))
+			}
+
+			found = true
+			break
+		}
+
+		offset = invoicesResp.LastIndexOffset
+	}
+
+	if !found {
+		t.Fatalf("invoice not found")
+	}
+}
+
+// testSendMultiPathPaymentAdversarialLiquidity tests that the automatic MPP
+// splitter used by SendPaymentV2 is able to route a payment that no single
+// path can carry, across a topology where some of the intermediate hops have
+// been deliberately imbalanced. Unlike testSendToRouteMultiPath, which
+// constructs and dispatches the individual shards by hand via SendToRoute,
+// this test lets the router discover the split on its own, and asserts that
+// it honors the caller-supplied MaxParts and MaxShardSizeMsat constraints.
+func testSendMultiPathPaymentAdversarialLiquidity(net *lntest.NetworkHarness,
+	t *harnessTest) {
+
+	ctxb := context.Background()
+
+	// We'll set up a topology where Alice can reach Bob over two routes,
+	// neither of which can carry the full payment on its own, forcing
+	// the splitter to use both. We additionally skew the local/remote
+	// balance on the Alice->Carol channel by pushing funds to Carol at
+	// open time, so that Alice only has a small amount of outbound
+	// liquidity to shard through Carol.
+	const (
+		paymentAmt   = btcutil.Amount(300000)
+		maxParts     = uint32(4)
+		maxShardAmt  = paymentAmt / 2
+		chanAmt      = paymentAmt
+		carolPushAmt = paymentAmt * 3 / 4
+	)
+
+	_, _, cleanup := setupAdversarialLiquidityTopology(
+		net, t, chanAmt, carolPushAmt,
+	)
+	defer cleanup()
+
+	// Make Bob create an invoice for Alice to pay.
+	payReqs, rHashes, invoices, err := createPayReqs(
+		net.Bob, paymentAmt, 1,
+	)
+	if err != nil {
+		t.Fatalf("unable to create pay reqs: %v", err)
+	}
+
+	rHash := rHashes[0]
+	payReq := payReqs[0]
+
+	// Concurrently subscribe to the payment via TrackPaymentV2, keyed on
+	// rHash. Its update stream is independent of the one SendPaymentV2
+	// returns below, so it lets us verify that the shard count and
+	// settled HTLC set it reports agree with what the sender itself
+	// observes, rather than trusting a single stream's view of the
+	// payment.
+	trackUpdates := make(chan *lnrpc.Payment, 1)
+	trackErrs := make(chan error, 1)
+	go func() {
+		trackCtx, cancel := context.WithTimeout(ctxb, defaultTimeout)
+		defer cancel()
+
+		trackStream, err := net.Alice.RouterClient.TrackPaymentV2(
+			trackCtx, &routerrpc.TrackPaymentRequest{
+				PaymentHash: rHash,
+			},
+		)
+		if err != nil {
+			trackErrs <- err
+			return
+		}
+
+		var last *lnrpc.Payment
+		for {
+			update, err := trackStream.Recv()
+			if err != nil {
+				trackErrs <- err
+				return
+			}
+
+			last = update
+			if update.Status != lnrpc.Payment_IN_FLIGHT {
+				break
+			}
+		}
+
+		trackUpdates <- last
+	}()
+
+	// Let Alice pay the invoice, relying entirely on the router's
+	// automatic MPP splitter to discover a valid set of shards. We cap
+	// the number of parts and the size of any individual shard so that
+	// we can assert the splitter actually respects those constraints.
+	sendReq := &routerrpc.SendPaymentRequest{
+		PaymentRequest:   payReq,
+		TimeoutSeconds:   int32(defaultTimeout.Seconds()),
+		FeeLimitMsat:     noFeeLimitMsat,
+		MaxParts:         maxParts,
+		MaxShardSizeMsat: int64(maxShardAmt * 1000),
+	}
+
+	ctxt, _ := context.WithTimeout(ctxb, defaultTimeout)
+	stream, err := net.Alice.RouterClient.SendPaymentV2(ctxt, sendReq)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+
+	var lastUpdate *lnrpc.Payment
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("unable to get payment update: %v", err)
+		}
+
+		lastUpdate = update
+		if update.Status != lnrpc.Payment_IN_FLIGHT {
+			break
+		}
+	}
+
+	if lastUpdate.Status != lnrpc.Payment_SUCCEEDED {
+		t.Fatalf("payment did not succeed: %v", lastUpdate.Status)
+	}
+
+	if !bytes.Equal(lastUpdate.PaymentPreimage, invoices[0].RPreimage) {
+		t.Fatalf("preimage doesn't match")
+	}
+
+	// The router had to shard across both Alice->Carol->Bob and
+	// Alice->Dave->Bob to complete the payment, and it must have
+	// honored the shard size cap we imposed.
+	succeeded := 0
+	for _, htlc := range lastUpdate.Htlcs {
+		if htlc.Status != lnrpc.HTLCAttempt_SUCCEEDED {
+			continue
+		}
+		succeeded++
+
+		shardAmt := btcutil.Amount(htlc.Route.TotalAmtMsat / 1000)
+		if shardAmt > maxShardAmt {
+			t.Fatalf("shard amount %v exceeded MaxShardSizeMsat "+
+				"cap of %v", shardAmt, maxShardAmt)
+		}
+	}
+
+	if succeeded < 2 {
+		t.Fatalf("expected payment to be split into at least two "+
+			"shards, only found %v", succeeded)
+	}
+
+	if succeeded > int(maxParts) {
+		t.Fatalf("payment used %v shards, exceeding MaxParts of %v",
+			succeeded, maxParts)
+	}
+
+	// TrackPaymentV2's independent subscription should agree with the
+	// shard count that SendPaymentV2 itself reported.
+	select {
+	case trackUpdate := <-trackUpdates:
+		if trackUpdate.Status != lnrpc.Payment_SUCCEEDED {
+			t.Fatalf("tracked payment did not succeed: %v",
+				trackUpdate.Status)
+		}
+
+		trackSucceeded := 0
+		for _, htlc := range trackUpdate.Htlcs {
+			if htlc.Status == lnrpc.HTLCAttempt_SUCCEEDED {
+				trackSucceeded++
+			}
+		}
+
+		if trackSucceeded != succeeded {
+			t.Fatalf("TrackPaymentV2 reported %v succeeded "+
+				"shards, SendPaymentV2 reported %v",
+				trackSucceeded, succeeded)
+		}
+	case err := <-trackErrs:
+		t.Fatalf("unable to track payment: %v", err)
+	}
+
+	// Finally, the invoice on Bob's end should reflect the same number
+	// of settled HTLCs as the payment.
+	assertInvoiceSettledWithHtlcs(t, net.Bob, rHash, succeeded)
+}
+
+// testSendPaymentAdaptiveShardSize tests that the payment lifecycle reshards
+// a failing HTLC attempt into two smaller shards when a hop along its route
+// reports insufficient balance, rather than giving up on that shard
+// altogether. The topology below is built so that an initial split into 3
+// equal shards cannot succeed, because the Alice->Carol channel only has
+// enough outbound liquidity for a shard half that size, while a finer split
+// can route around the shortfall.
+func testSendPaymentAdaptiveShardSize(net *lntest.NetworkHarness,
+	t *harnessTest) {
+
+	ctxb := context.Background()
+
+	// Alice -- Carol ---- Bob
+	//      \              /
+	//       \__ Dave ____/
+	//
+	// The Alice->Carol channel is starved of outbound liquidity on
+	// Alice's side, so any shard wider than 1/6th of the payment amount
+	// routed that way will be rejected with a temporary channel failure,
+	// forcing the initial 3-shard split to be subdivided further.
+	const (
+		paymentAmt   = btcutil.Amount(300000)
+		maxParts     = uint32(6)
+		minShardAmt  = btcutil.Amount(10000)
+		chanAmt      = paymentAmt
+		carolPushAmt = paymentAmt - paymentAmt/6
+	)
+
+	_, _, cleanup := setupAdversarialLiquidityTopology(
+		net, t, chanAmt, carolPushAmt,
+	)
+	defer cleanup()
+
+	payReqs, rHashes, invoices, err := createPayReqs(
+		net.Bob, paymentAmt, 1,
+	)
+	if err != nil {
+		t.Fatalf("unable to create pay reqs: %v", err)
+	}
+
+	rHash := rHashes[0]
+	payReq := payReqs[0]
+
+	// An even 3-way split is impossible given the liquidity above, and the
+	// request only caps the number of parts and sets a shard-size floor;
+	// it does not fix an initial split. The payment should only succeed
+	// if the lifecycle notices the failures on the oversized shard and
+	// adaptively halves
+	// it until it fits, up to the MaxParts budget and down to
+	// MinShardSizeMsat.
+	sendReq := &routerrpc.SendPaymentRequest{
+		PaymentRequest:   payReq,
+		TimeoutSeconds:   int32(defaultTimeout.Seconds()),
+		FeeLimitMsat:     noFeeLimitMsat,
+		MaxParts:         maxParts,
+		MinShardSizeMsat: int64(minShardAmt * 1000),
+	}
+
+	ctxt, _ := context.WithTimeout(ctxb, defaultTimeout)
+	stream, err := net.Alice.RouterClient.SendPaymentV2(ctxt, sendReq)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+
+	var lastUpdate *lnrpc.Payment
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("unable to get payment update: %v", err)
+		}
+
+		lastUpdate = update
+		if update.Status != lnrpc.Payment_IN_FLIGHT {
+			break
+		}
+	}
+
+	if lastUpdate.Status != lnrpc.Payment_SUCCEEDED {
+		t.Fatalf("payment did not succeed: %v", lastUpdate.Status)
+	}
+
+	if !bytes.Equal(lastUpdate.PaymentPreimage, invoices[0].RPreimage) {
+		t.Fatalf("preimage doesn't match")
+	}
+
+	// The final HTLC set should reflect the adaptive subdivision. It's
+	// not enough to see more than 3 succeeded shards: the ordinary MPP
+	// splitter could also arrive at a >3-way split on its own without
+	// ever hitting the liquidity shortfall. What proves resharding
+	// actually happened is that the oversized shard's route was tried
+	// and failed with a temporary channel failure before the lifecycle
+	// fell back to a finer split.
+	succeeded := 0
+	failedTemporaryChannelFailure := 0
+	for _, htlc := range lastUpdate.Htlcs {
+		switch htlc.Status {
+		case lnrpc.HTLCAttempt_SUCCEEDED:
+			succeeded++
+
+		case lnrpc.HTLCAttempt_FAILED:
+			if htlc.Failure != nil &&
+				htlc.Failure.Code ==
+					lnrpc.Failure_TEMPORARY_CHANNEL_FAILURE {
+
+				failedTemporaryChannelFailure++
+			}
+		}
+	}
+
+	if failedTemporaryChannelFailure == 0 {
+		t.Fatalf("expected at least one failed attempt with a " +
+			"temporary channel failure from the oversized shard, " +
+			"got none")
+	}
+
+	if succeeded <= 3 {
+		t.Fatalf("expected adaptive resharding to produce more than "+
+			"3 successful shards, got %v", succeeded)
+	}
+
+	if succeeded > int(maxParts) {
+		t.Fatalf("payment used %v shards, exceeding MaxParts of %v",
+			succeeded, maxParts)
+	}
+
+	// Finally, the invoice on Bob's end should reflect the same number
+	// of settled HTLCs as the payment.
+	assertInvoiceSettledWithHtlcs(t, net.Bob, rHash, succeeded)
+}
@@ -0,0 +1,51 @@
+package routing
+
+import (
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// paymentShard describes a single HTLC attempt the payment lifecycle still
+// needs to launch, carrying the amount it should be sent for.
+type paymentShard struct {
+	amt lnwire.MilliSatoshi
+}
+
+// shardResplitter tracks per-attempt failure reasons for a single payment
+// and decides, when an attempt fails, whether the lifecycle should resplit
+// the failed shard rather than retrying it unchanged or giving up on it.
+type shardResplitter struct {
+	tracker *shardFailureTracker
+	payment *LightningPayment
+}
+
+// newShardResplitter returns a shardResplitter for payment, ready to track
+// attempt failures as they're reported by the switch.
+func newShardResplitter(payment *LightningPayment) *shardResplitter {
+	return &shardResplitter{
+		tracker: newShardFailureTracker(),
+		payment: payment,
+	}
+}
+
+// handleAttemptFailure takes the outcome of an in-flight HTLC attempt that
+// came back failed. It records the failure reason and, if it indicates the
+// shard was simply too large for the liquidity along its route, returns the
+// replacement shards the lifecycle should launch in its place. ok is false
+// when the existing retry-or-abandon behavior should be used instead, either
+// because the failure wasn't liquidity-related or because halving the shard
+// would take it below the payment's configured floor.
+func (r *shardResplitter) handleAttemptFailure(attemptID uint64,
+	failedAmt lnwire.MilliSatoshi, reason lnwire.FailureMessage) (
+	[]paymentShard, bool) {
+
+	r.tracker.recordFailure(attemptID, reason)
+
+	half, remainder, ok := r.tracker.ResplitFailedShard(
+		attemptID, failedAmt, r.payment,
+	)
+	if !ok {
+		return nil, false
+	}
+
+	return []paymentShard{{amt: half}, {amt: remainder}}, true
+}
@@ -0,0 +1,78 @@
+package routing
+
+import (
+	"time"
+
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// LightningPayment describes a payment to be made over the Lightning
+// Network, along with the constraints the payment lifecycle must respect
+// while attempting to complete it.
+type LightningPayment struct {
+	// Target is the node in which the payment should be routed towards.
+	Target route.Vertex
+
+	// Amount is the total value, across all shards, that the payment
+	// must deliver to the recipient.
+	Amount lnwire.MilliSatoshi
+
+	// FeeLimit is the maximum fee, in total, that may be paid across all
+	// shards of this payment.
+	FeeLimit lnwire.MilliSatoshi
+
+	// PaymentHash is the r-hash of the payment to be made.
+	PaymentHash lntypes.Hash
+
+	// FinalCLTVDelta is the CTLV delta to use for the final hop on the
+	// route.
+	FinalCLTVDelta uint16
+
+	// PayAttemptTimeout is a timeout value that abandons the payment if
+	// it isn't completed within this time.
+	PayAttemptTimeout time.Duration
+
+	// RouteHints represents the different routing hints that can be
+	// used to assist in reaching the payment's destination via private
+	// channels.
+	RouteHints [][]HopHint
+
+	// MaxParts is the maximum number of partial payments that may be
+	// used to complete the full amount.
+	MaxParts uint32
+
+	// MaxShardAmt is the largest amount permitted for a single shard. A
+	// nil value leaves the lifecycle free to choose shard sizes up to
+	// the full payment amount.
+	MaxShardAmt *lnwire.MilliSatoshi
+
+	// MinShardAmt is the smallest amount a shard may be split down to
+	// when the lifecycle adaptively resplits a failing HTLC attempt. A
+	// nil value defaults to MinShardSizeMsat.
+	MinShardAmt *lnwire.MilliSatoshi
+}
+
+// HopHint is a routing hint that contains the information required to use a
+// private channel during path finding. This is a minimal stand-in for the
+// real hop hint type used by route hints; it only carries what this package
+// needs.
+type HopHint struct {
+	// NodeID is the public key of the node at the start of the channel.
+	NodeID route.Vertex
+
+	// ChannelID is the unique identifier of the channel.
+	ChannelID uint64
+}
+
+// minShardAmt returns the floor below which a shard belonging to this
+// payment must not be split, falling back to MinShardSizeMsat when the
+// caller didn't set one explicitly.
+func (p *LightningPayment) minShardAmt() lnwire.MilliSatoshi {
+	if p.MinShardAmt != nil {
+		return *p.MinShardAmt
+	}
+
+	return MinShardSizeMsat
+}
@@ -0,0 +1,109 @@
+package routing
+
+import (
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// MinShardSizeMsat is the default floor a shard is allowed to be split down
+// to by adaptive resharding. It is used whenever a LightningPayment doesn't
+// specify its own MinShardAmt.
+const MinShardSizeMsat = lnwire.MilliSatoshi(10_000_000)
+
+// shardFailureKind enumerates the attempt failure reasons the payment
+// lifecycle treats as signals that a shard was simply too large for the
+// liquidity along its route, rather than a routing or cryptographic error
+// that resharding can't fix.
+type shardFailureKind int
+
+const (
+	// shardFailureOther covers any failure that isn't a liquidity
+	// shortfall; resharding never applies to these.
+	shardFailureOther shardFailureKind = iota
+
+	// shardFailureInsufficientBalance is recorded when a hop along the
+	// route fails the HTLC because it can't forward the requested
+	// amount.
+	shardFailureInsufficientBalance
+)
+
+// classifyShardFailure maps a wire failure message to a shardFailureKind,
+// determining whether the payment lifecycle should attempt to resplit the
+// failed shard rather than simply retrying it unchanged or abandoning it.
+func classifyShardFailure(reason lnwire.FailureMessage) shardFailureKind {
+	switch reason.(type) {
+	case *lnwire.FailTemporaryChannelFailure,
+		*lnwire.FailAmountBelowMinimum,
+		*lnwire.FailFeeInsufficient:
+
+		return shardFailureInsufficientBalance
+
+	default:
+		return shardFailureOther
+	}
+}
+
+// shardFailureTracker records, per in-flight HTLC attempt, the most recent
+// failure reason observed for it. The payment lifecycle consults this after
+// an attempt fails to decide whether the failing shard should be resplit.
+type shardFailureTracker struct {
+	failures map[uint64]shardFailureKind
+}
+
+// newShardFailureTracker returns a shardFailureTracker ready for use.
+func newShardFailureTracker() *shardFailureTracker {
+	return &shardFailureTracker{
+		failures: make(map[uint64]shardFailureKind),
+	}
+}
+
+// recordFailure stores the failure reason observed for the HTLC attempt
+// identified by attemptID.
+func (s *shardFailureTracker) recordFailure(attemptID uint64,
+	reason lnwire.FailureMessage) {
+
+	s.failures[attemptID] = classifyShardFailure(reason)
+}
+
+// shouldResplit reports whether the attempt identified by attemptID failed
+// for a reason indicating the shard itself was too large for the liquidity
+// along its route.
+func (s *shardFailureTracker) shouldResplit(attemptID uint64) bool {
+	kind, ok := s.failures[attemptID]
+	return ok && kind == shardFailureInsufficientBalance
+}
+
+// resplitShard halves a failed shard's amount, returning the two amounts the
+// lifecycle should retry with in its place. The final return value is false
+// once halving would take either half below minShardAmt, signalling that the
+// caller should give up on the shard instead of subdividing it further.
+func resplitShard(failedAmt, minShardAmt lnwire.MilliSatoshi) (
+	lnwire.MilliSatoshi, lnwire.MilliSatoshi, bool) {
+
+	half := failedAmt / 2
+	remainder := failedAmt - half
+
+	if half < minShardAmt {
+		return 0, 0, false
+	}
+
+	return half, remainder, true
+}
+
+// ResplitFailedShard decides, given the most recent failure recorded for
+// attemptID, whether a failed shard of failedAmt should be subdivided. When
+// it should, it returns the two amounts the payment lifecycle should enqueue
+// as new HTLC attempts in place of the failed one, summing back to
+// failedAmt. ok is false either when the failure wasn't liquidity-related,
+// or when halving would take the shard below payment's configured
+// MinShardAmt, in which case the lifecycle should give up on the shard
+// instead of resplitting it.
+func (s *shardFailureTracker) ResplitFailedShard(attemptID uint64,
+	failedAmt lnwire.MilliSatoshi, payment *LightningPayment) (
+	lnwire.MilliSatoshi, lnwire.MilliSatoshi, bool) {
+
+	if !s.shouldResplit(attemptID) {
+		return 0, 0, false
+	}
+
+	return resplitShard(failedAmt, payment.minShardAmt())
+}